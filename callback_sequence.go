@@ -0,0 +1,57 @@
+package gorm
+
+import "fmt"
+
+// sequenceDialect is implemented by dialects whose primary keys can be
+// generated from a named sequence instead of an IDENTITY/AUTO_INCREMENT
+// column.
+type sequenceDialect interface {
+	HasSequence(name string) bool
+	CreateSequence(name string) error
+	DropSequence(name string) error
+	NextSequenceValueSQL(name string) string
+
+	// SequencedPrimaryKeyName returns the sequence backing field's value on
+	// insert, and ok=false when field isn't sequence-backed at all (no
+	// SEQUENCE tag and sequence mode isn't enabled on the dialect).
+	SequencedPrimaryKeyName(tableName string, field *StructField) (name string, ok bool)
+}
+
+func init() {
+	DefaultCallback.Create().Before("gorm:create").Register("gorm:sequence", sequenceCallback)
+}
+
+// sequenceCallback populates a sequence-backed primary key before the plain
+// insert callback runs, for fields tagged `gorm:"AUTO_INCREMENT;SEQUENCE:name"`
+// or any primary key when the dialect has UseSequences(true) set.
+func sequenceCallback(scope *Scope) {
+	dialect, ok := scope.Dialect().(sequenceDialect)
+	if !ok {
+		return
+	}
+
+	field := scope.PrimaryField()
+	if field == nil || !field.IsBlank {
+		return
+	}
+
+	seqName, ok := dialect.SequencedPrimaryKeyName(scope.TableName(), field.StructField)
+	if !ok {
+		return
+	}
+
+	if !dialect.HasSequence(seqName) {
+		if err := dialect.CreateSequence(seqName); err != nil {
+			scope.Err(err)
+			return
+		}
+	}
+
+	var id int64
+	query := fmt.Sprintf("SELECT %s %s", dialect.NextSequenceValueSQL(seqName), scope.Dialect().SelectFromDummyTable())
+	if err := scope.NewDB().Raw(query).Row().Scan(&id); err != nil {
+		scope.Err(err)
+		return
+	}
+	scope.Err(field.Set(id))
+}
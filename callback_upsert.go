@@ -0,0 +1,60 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	DefaultCallback.Create().Replace("gorm:create", createOrUpsertCallback)
+}
+
+// createOrUpsertCallback replaces the plain "gorm:create" callback so that
+// an upsert configured via DB.Upsert runs as a single dialect-native
+// statement instead of a plain INSERT. Replacing only this named callback
+// (rather than skipping ahead with SkipLeft) leaves the rest of the create
+// chain — save_before_associations, force_reload_after_create,
+// save_after_associations, after_create, commit_or_rollback_transaction —
+// running exactly as it would for a plain Create.
+func createOrUpsertCallback(scope *Scope) {
+	value, ok := scope.Get("gorm:upsert_clause")
+	if !ok {
+		createCallback(scope)
+		return
+	}
+
+	clause, ok := value.(*upsertClause)
+	if !ok {
+		createCallback(scope)
+		return
+	}
+
+	upserter, ok := scope.Dialect().(Upserter)
+	if !ok {
+		// Silently falling through to a plain INSERT here would violate the
+		// conflict semantics the caller asked for (and blow up on the first
+		// conflicting row with a constraint error instead). Fail loudly and
+		// specifically instead.
+		scope.Err(fmt.Errorf("gorm: dialect %q does not implement gorm.Upserter, db.Upsert is not supported", scope.Dialect().GetName()))
+		return
+	}
+
+	var columns []string
+	var values []interface{}
+	for _, field := range scope.Fields() {
+		if scope.changeableField(field) && !field.IsIgnored && !(field.IsPrimaryKey && field.IsBlank) {
+			columns = append(columns, field.DBName)
+			values = append(values, field.Field.Interface())
+		}
+	}
+
+	sql, _ := upserter.BuildUpsertSQL(scope.TableName(), columns, clause.conflictColumns, clause.updateColumns)
+	// Bind each value through the dialect, turning every "?" into the
+	// dialect's own bind variable ($n for postgres) in column order.
+	scope.SQLVars = nil
+	for _, v := range values {
+		sql = strings.Replace(sql, "?", scope.AddToVars(v), 1)
+	}
+	scope.Raw(sql)
+	scope.Exec()
+}
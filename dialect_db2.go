@@ -0,0 +1,204 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type db2 struct {
+	commonDialect
+}
+
+func init() {
+	RegisterDialect("db2", &db2{})
+}
+
+func (db2) GetName() string {
+	return "db2"
+}
+
+func (db2) Quote(key string) string {
+	return fmt.Sprintf(`"%s"`, key)
+}
+
+func (d *db2) DataTypeOf(field *StructField) string {
+	var dataValue, sqlType, size, additionalType = ParseFieldStructForDialect(field, d)
+
+	if sqlType == "" {
+		switch dataValue.Kind() {
+		case reflect.Bool:
+			sqlType = "CHAR(1) FOR BIT DATA"
+		case reflect.Int8, reflect.Int16:
+			sqlType = "SMALLINT"
+		case reflect.Int, reflect.Int32:
+			if d.fieldCanAutoIncrement(field) {
+				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+				sqlType = "INTEGER GENERATED ALWAYS AS IDENTITY"
+			} else {
+				sqlType = "INTEGER"
+			}
+		case reflect.Uint8, reflect.Uint16:
+			sqlType = "SMALLINT"
+		case reflect.Uint, reflect.Uint32, reflect.Uintptr:
+			sqlType = "INTEGER"
+		case reflect.Int64:
+			if d.fieldCanAutoIncrement(field) {
+				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+				sqlType = "BIGINT GENERATED ALWAYS AS IDENTITY"
+			} else {
+				sqlType = "BIGINT"
+			}
+		case reflect.Uint64:
+			sqlType = "BIGINT"
+		case reflect.Float32, reflect.Float64:
+			sqlType = "DOUBLE"
+		case reflect.String:
+			if size > 0 && size < 32672 {
+				sqlType = fmt.Sprintf("VARCHAR(%d)", size)
+			} else {
+				sqlType = "CLOB"
+			}
+		case reflect.Struct:
+			if _, ok := dataValue.Interface().(time.Time); ok {
+				sqlType = "TIMESTAMP"
+			}
+		default:
+			if IsByteArrayOrSlice(dataValue) {
+				sqlType = "BLOB"
+			}
+		}
+	}
+
+	if sqlType == "" {
+		panic(fmt.Sprintf("invalid sql type %s (%s) in field %s for db2", dataValue.Type().Name(), dataValue.Kind().String(), field.Name))
+	}
+
+	if strings.TrimSpace(additionalType) == "" {
+		return sqlType
+	}
+	return fmt.Sprintf("%v %v", sqlType, additionalType)
+}
+
+func (d db2) RemoveIndex(tableName string, indexName string) error {
+	_, err := d.db.Exec(fmt.Sprintf("DROP INDEX %v", d.Quote(indexName)))
+	return err
+}
+
+func (d db2) ModifyColumn(tableName string, columnName string, typ string) error {
+	_, err := d.db.Exec(fmt.Sprintf("ALTER TABLE %v ALTER COLUMN %v SET DATA TYPE %v", tableName, columnName, typ))
+	return err
+}
+
+func (d db2) LimitAndOffsetSQL(limit, offset interface{}) (sql string, err error) {
+	parsedOffset := int64(0)
+	if offset != nil {
+		parsedOffset, err = d.parseInt(offset)
+		if err != nil {
+			return "", err
+		}
+		if parsedOffset < 0 {
+			parsedOffset = 0
+		}
+	}
+
+	if limit != nil {
+		parsedLimit, err := d.parseInt(limit)
+		if err != nil {
+			return "", err
+		}
+		if parsedLimit >= 0 {
+			if parsedOffset > 0 {
+				sql = fmt.Sprintf(" OFFSET %d ROWS FETCH FIRST %d ROWS ONLY", parsedOffset, parsedLimit)
+			} else {
+				sql = fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", parsedLimit)
+			}
+		}
+	} else if parsedOffset > 0 {
+		sql = fmt.Sprintf(" OFFSET %d ROWS", parsedOffset)
+	}
+	return
+}
+
+func (d db2) HasTable(tableName string) bool {
+	_, tableName = currentDatabaseAndTable(&d, tableName)
+	var count int
+	d.db.QueryRow("SELECT count(*) FROM SYSCAT.TABLES WHERE TABNAME = ?", strings.ToUpper(tableName)).Scan(&count)
+	return count > 0
+}
+
+func (d db2) HasColumn(tableName string, columnName string) bool {
+	_, tableName = currentDatabaseAndTable(&d, tableName)
+	var count int
+	d.db.QueryRow("SELECT count(*) FROM SYSCAT.COLUMNS WHERE TABNAME = ? AND COLNAME = ?", strings.ToUpper(tableName), strings.ToUpper(columnName)).Scan(&count)
+	return count > 0
+}
+
+func (d db2) HasIndex(tableName string, indexName string) bool {
+	_, tableName = currentDatabaseAndTable(&d, tableName)
+	var count int
+	d.db.QueryRow("SELECT count(*) FROM SYSCAT.INDEXES WHERE TABNAME = ? AND INDNAME = ?", strings.ToUpper(tableName), strings.ToUpper(indexName)).Scan(&count)
+	return count > 0
+}
+
+func (d db2) HasForeignKey(tableName string, foreignKeyName string) bool {
+	_, tableName = currentDatabaseAndTable(&d, tableName)
+	var count int
+	d.db.QueryRow("SELECT count(*) FROM SYSCAT.REFERENCES WHERE TABNAME = ? AND CONSTNAME = ?", strings.ToUpper(tableName), strings.ToUpper(foreignKeyName)).Scan(&count)
+	return count > 0
+}
+
+// CurrentDatabase returns the current schema for the connected session.
+func (d db2) CurrentDatabase() (name string) {
+	d.db.QueryRow("SELECT CURRENT SCHEMA FROM SYSIBM.SYSDUMMY1").Scan(&name)
+	return
+}
+
+func (db2) SelectFromDummyTable() string {
+	return "FROM SYSIBM.SYSDUMMY1"
+}
+
+// BuildUpsertSQL implements Upserter using DB2's MERGE statement. The
+// returned SQL has one "?" placeholder per entry in columns, in order; the
+// caller binds each row's column values positionally when executing it.
+func (d db2) BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{}) {
+	conflictSet := make(map[string]bool, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflictSet[c] = true
+	}
+
+	var srcSelect, onClauses []string
+	for _, c := range columns {
+		srcSelect = append(srcSelect, fmt.Sprintf("? AS %s", d.Quote(c)))
+	}
+	for _, c := range conflictColumns {
+		onClauses = append(onClauses, fmt.Sprintf("target.%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var updateSet []string
+	for _, c := range updateColumns {
+		if conflictSet[c] {
+			continue
+		}
+		updateSet = append(updateSet, fmt.Sprintf("%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var insertCols, insertVals []string
+	for _, c := range columns {
+		insertCols = append(insertCols, d.Quote(c))
+		insertVals = append(insertVals, fmt.Sprintf("src.%s", d.Quote(c)))
+	}
+
+	sql := fmt.Sprintf(
+		"MERGE INTO %s AS target USING (SELECT %s FROM SYSIBM.SYSDUMMY1) AS src ON (%s) WHEN MATCHED THEN UPDATE SET %s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		d.Quote(tableName),
+		strings.Join(srcSelect, ", "),
+		strings.Join(onClauses, " AND "),
+		strings.Join(updateSet, ", "),
+		strings.Join(insertCols, ", "),
+		strings.Join(insertVals, ", "),
+	)
+
+	return sql, nil
+}
@@ -0,0 +1,41 @@
+package gorm
+
+import "testing"
+
+func TestDb2Quote(t *testing.T) {
+	d := db2{}
+	if got, want := d.Quote("name"), `"name"`; got != want {
+		t.Errorf("Quote(%q) = %q, want %q", "name", got, want)
+	}
+}
+
+func TestDb2LimitAndOffsetSQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit, offset interface{}
+		want          string
+	}{
+		{"limit only", 10, nil, " FETCH FIRST 10 ROWS ONLY"},
+		{"limit and offset", 10, 20, " OFFSET 20 ROWS FETCH FIRST 10 ROWS ONLY"},
+		{"offset only", nil, 20, " OFFSET 20 ROWS"},
+	}
+
+	d := db2{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.LimitAndOffsetSQL(tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("LimitAndOffsetSQL returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LimitAndOffsetSQL(%v, %v) = %q, want %q", tt.limit, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDb2SelectFromDummyTable(t *testing.T) {
+	if got, want := (db2{}).SelectFromDummyTable(), "FROM SYSIBM.SYSDUMMY1"; got != want {
+		t.Errorf("SelectFromDummyTable() = %q, want %q", got, want)
+	}
+}
@@ -14,10 +14,64 @@ var dmsqlIndexRegex = regexp.MustCompile(`^(.+)\((\d+)\)$`)
 
 type dmsql struct {
 	commonDialect
+
+	// oracleCompatMode reports whether the connected DM server is running
+	// in Oracle-compatible mode, which changes pagination and identity
+	// syntax. Defaults to true since that is how DM ships out of the box.
+	oracleCompatMode bool
+
+	// useSequences makes the dialect generate primary keys from a named
+	// sequence instead of an IDENTITY column, for DM instances that run
+	// with MySQL-compat identity columns disabled.
+	useSequences bool
 }
 
 func init() {
-	RegisterDialect("dm", &dmsql{})
+	RegisterDialect("dm", &dmsql{oracleCompatMode: true})
+}
+
+// SetOracleCompatMode toggles whether the dialect should generate SQL for
+// DM's Oracle-compatible mode (OFFSET/FETCH pagination) instead of its
+// MySQL-compatible mode (LIMIT/OFFSET pagination).
+func (d *dmsql) SetOracleCompatMode(compat bool) {
+	d.oracleCompatMode = compat
+}
+
+// SetDB turns Oracle-compatible mode on the first time a database is
+// attached, so a dialect created by Open starts in that mode; later calls
+// (such as from Begin) keep the current setting.
+func (d *dmsql) SetDB(db SQLCommon) {
+	if d.db == nil {
+		d.oracleCompatMode = true
+	}
+	d.commonDialect.SetDB(db)
+}
+
+// UseSequences makes AUTO_INCREMENT fields that don't name an explicit
+// SEQUENCE tag fall back to a sequence named seq_<table>_<column>, instead
+// of an IDENTITY(1,1) column.
+func (d *dmsql) UseSequences(use bool) {
+	d.useSequences = use
+}
+
+// SequencedPrimaryKeyName reports the sequence that should generate field's
+// value on insert, if any. It returns ok=false when field has no explicit
+// `gorm:"SEQUENCE:name"` tag and UseSequences(true) hasn't been set, in
+// which case the column is expected to carry its own IDENTITY(1,1) clause
+// instead. Otherwise it returns the tagged name, or the default
+// seq_<table>_<column> when no name was given.
+func (d *dmsql) SequencedPrimaryKeyName(tableName string, field *StructField) (name string, ok bool) {
+	if name, ok := field.TagSettingsGet("SEQUENCE"); ok {
+		// A bare SEQUENCE tag is stored with its own key as the value.
+		if name != "" && name != "SEQUENCE" {
+			return name, true
+		}
+		return fmt.Sprintf("seq_%s_%s", tableName, field.DBName), true
+	}
+	if !d.useSequences {
+		return "", false
+	}
+	return fmt.Sprintf("seq_%s_%s", tableName, field.DBName), true
 }
 
 func (dmsql) GetName() string {
@@ -25,7 +79,7 @@ func (dmsql) GetName() string {
 }
 
 func (dmsql) Quote(key string) string {
-	return fmt.Sprintf("`%s`", key)
+	return fmt.Sprintf(`"%s"`, key)
 }
 
 func (d *dmsql) DataTypeOf(field *StructField) string {
@@ -46,42 +100,42 @@ func (d *dmsql) DataTypeOf(field *StructField) string {
 		case reflect.Int8:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "tinyint AUTO_INCREMENT"
+				sqlType = "tinyint IDENTITY(1,1)"
 			} else {
 				sqlType = "tinyint"
 			}
 		case reflect.Int, reflect.Int16, reflect.Int32:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "int AUTO_INCREMENT"
+				sqlType = "int IDENTITY(1,1)"
 			} else {
 				sqlType = "int"
 			}
 		case reflect.Uint8:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "tinyint unsigned AUTO_INCREMENT"
+				sqlType = "tinyint unsigned IDENTITY(1,1)"
 			} else {
 				sqlType = "tinyint unsigned"
 			}
 		case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uintptr:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "int unsigned AUTO_INCREMENT"
+				sqlType = "int unsigned IDENTITY(1,1)"
 			} else {
 				sqlType = "int unsigned"
 			}
 		case reflect.Int64:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "bigint AUTO_INCREMENT"
+				sqlType = "bigint IDENTITY(1,1)"
 			} else {
 				sqlType = "bigint"
 			}
 		case reflect.Uint64:
 			if d.fieldCanAutoIncrement(field) {
 				field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
-				sqlType = "bigint unsigned AUTO_INCREMENT"
+				sqlType = "bigint unsigned IDENTITY(1,1)"
 			} else {
 				sqlType = "bigint unsigned"
 			}
@@ -117,6 +171,15 @@ func (d *dmsql) DataTypeOf(field *StructField) string {
 		}
 	}
 
+	// A sequence-backed primary key gets its value from NextSequenceValueSQL
+	// on insert, so the column itself must not also be IDENTITY.
+	if strings.HasSuffix(sqlType, "IDENTITY(1,1)") {
+		_, hasSequenceTag := field.TagSettingsGet("SEQUENCE")
+		if hasSequenceTag || d.useSequences {
+			sqlType = strings.TrimSpace(strings.TrimSuffix(sqlType, "IDENTITY(1,1)"))
+		}
+	}
+
 	if sqlType == "" {
 		panic(fmt.Sprintf("invalid sql type %s (%s) in field %s for dmsql", dataValue.Type().Name(), dataValue.Kind().String(), field.Name))
 	}
@@ -138,24 +201,50 @@ func (d dmsql) ModifyColumn(tableName string, columnName string, typ string) err
 }
 
 func (d dmsql) LimitAndOffsetSQL(limit, offset interface{}) (sql string, err error) {
+	if !d.oracleCompatMode {
+		if limit != nil {
+			parsedLimit, err := d.parseInt(limit)
+			if err != nil {
+				return "", err
+			}
+			if parsedLimit >= 0 {
+				sql += fmt.Sprintf(" LIMIT %d", parsedLimit)
+
+				if offset != nil {
+					parsedOffset, err := d.parseInt(offset)
+					if err != nil {
+						return "", err
+					}
+					if parsedOffset >= 0 {
+						sql += fmt.Sprintf(" OFFSET %d", parsedOffset)
+					}
+				}
+			}
+		}
+		return sql, nil
+	}
+
+	parsedOffset := int64(0)
+	if offset != nil {
+		parsedOffset, err = d.parseInt(offset)
+		if err != nil {
+			return "", err
+		}
+		if parsedOffset < 0 {
+			parsedOffset = 0
+		}
+	}
+
 	if limit != nil {
 		parsedLimit, err := d.parseInt(limit)
 		if err != nil {
 			return "", err
 		}
 		if parsedLimit >= 0 {
-			sql += fmt.Sprintf(" LIMIT %d", parsedLimit)
-
-			if offset != nil {
-				parsedOffset, err := d.parseInt(offset)
-				if err != nil {
-					return "", err
-				}
-				if parsedOffset >= 0 {
-					sql += fmt.Sprintf(" OFFSET %d", parsedOffset)
-				}
-			}
+			sql = fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", parsedOffset, parsedLimit)
 		}
+	} else if parsedOffset > 0 {
+		sql = fmt.Sprintf(" OFFSET %d ROWS", parsedOffset)
 	}
 	return
 }
@@ -170,40 +259,27 @@ func (d dmsql) HasForeignKey(tableName string, foreignKeyName string) bool {
 func (d dmsql) HasTable(tableName string) bool {
 	currentTableSpace, tableName := currentDatabaseAndTable(&d, tableName)
 	var count int
-	d.db.QueryRow(fmt.Sprintf("SELECT count(*) FROM USER_TABLES WHERE TABLESPACE = `%s` AND TABLE_NAME = `%s` ", currentTableSpace, tableName)).Scan(&count)
+	d.db.QueryRow("SELECT count(*) FROM USER_TABLES WHERE TABLESPACE_NAME = ? AND TABLE_NAME = ?", currentTableSpace, tableName).Scan(&count)
 	return count > 0
 }
 
 func (d dmsql) HasIndex(tableName string, indexName string) bool {
 	currentTableSpace, tableName := currentDatabaseAndTable(&d, tableName)
 	var count int
-	d.db.QueryRow(fmt.Sprintf("SELECT count(*) FROM USER_INDEXS WHERE TABLESPACE = `%s` AND TABLE_NAME = `%s` AND INDEX_NAME = `%s` ", currentTableSpace, tableName, indexName)).Scan(&count)
-	//if rows, err := d.db.Query(fmt.Sprintf("SHOW INDEXES FROM `%s` FROM `%s` WHERE Key_name = ?", tableName, currentTableSpace), indexName); err != nil {
-	//	panic(err)
-	//} else {
-	//	defer rows.Close()
-	//	return rows.Next()
-	//}
+	d.db.QueryRow("SELECT count(*) FROM USER_INDEXES WHERE TABLESPACE_NAME = ? AND TABLE_NAME = ? AND INDEX_NAME = ?", currentTableSpace, tableName, indexName).Scan(&count)
 	return count > 0
 }
 
 func (d dmsql) HasColumn(tableName string, columnName string) bool {
 	_, tableName = currentDatabaseAndTable(&d, tableName)
 	var count int
-	d.db.QueryRow(fmt.Sprintf("SELECT count(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = `%s` AND COLUMN_NAME =`%s` ", tableName, columnName)).Scan(&count)
+	d.db.QueryRow("SELECT count(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?", tableName, columnName).Scan(&count)
 	return count > 0
-	//if rows, err := d.db.Query(fmt.Sprintf("SHOW COLUMNS FROM `%s` FROM `%s` WHERE Field = ?", tableName, currentDatabase), columnName); err != nil {
-	//	panic(err)
-	//} else {
-	//	defer rows.Close()
-	//	return rows.Next()
-	//}
 }
 
-//CurrentDatabase tablespace
+// CurrentDatabase returns the current schema (tablespace) for the connected session.
 func (d dmsql) CurrentDatabase() (name string) {
-	//d.db.QueryRow("SELECT DATABASE()").Scan(&name)
-	name = "blockchain"
+	d.db.QueryRow("SELECT SYS_CONTEXT('USERENV','CURRENT_SCHEMA') FROM DUAL").Scan(&name)
 	return
 }
 
@@ -211,6 +287,35 @@ func (dmsql) SelectFromDummyTable() string {
 	return "FROM DUAL"
 }
 
+// HasSequence reports whether a sequence of the given name exists in the
+// current schema.
+func (d dmsql) HasSequence(name string) bool {
+	var count int
+	d.db.QueryRow("SELECT count(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", name).Scan(&count)
+	return count > 0
+}
+
+// CreateSequence creates a sequence for use as a manual primary-key
+// generator, for DM instances that don't have identity columns enabled.
+func (d dmsql) CreateSequence(name string) error {
+	_, err := d.db.Exec(fmt.Sprintf("CREATE SEQUENCE %s START WITH 1 INCREMENT BY 1", d.Quote(name)))
+	return err
+}
+
+// DropSequence drops a sequence previously created by CreateSequence.
+func (d dmsql) DropSequence(name string) error {
+	_, err := d.db.Exec(fmt.Sprintf("DROP SEQUENCE %s", d.Quote(name)))
+	return err
+}
+
+// NextSequenceValueSQL returns the expression that advances and reads the
+// named sequence, for use in place of a generated identity value. The
+// sequence name is quoted the same way CreateSequence quoted it, so a
+// mixed-case default name still resolves.
+func (d dmsql) NextSequenceValueSQL(name string) string {
+	return fmt.Sprintf("%s.NEXTVAL", d.Quote(name))
+}
+
 func (d dmsql) BuildKeyName(kind, tableName string, fields ...string) string {
 	keyName := d.commonDialect.BuildKeyName(kind, tableName, fields...)
 	if utf8.RuneCountInString(keyName) <= 64 {
@@ -240,6 +345,50 @@ func (dmsql) NormalizeIndexAndColumn(indexName, columnName string) (string, stri
 	return indexName, columnName
 }
 
-func (dmsql) DefaultValueStr() string {
-	return "VALUES()"
-}
\ No newline at end of file
+// BuildUpsertSQL implements Upserter using DM's MERGE statement, since DM
+// has no MySQL-style "INSERT ... ON DUPLICATE KEY UPDATE VALUES()" support.
+// The returned SQL has one "?" placeholder per entry in columns, in order;
+// the caller binds each row's column values positionally when executing it.
+func (d dmsql) BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{}) {
+	conflictSet := make(map[string]bool, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflictSet[c] = true
+	}
+
+	var (
+		srcSelect []string
+		onClauses []string
+	)
+	for _, c := range columns {
+		srcSelect = append(srcSelect, fmt.Sprintf("? AS %s", d.Quote(c)))
+	}
+	for _, c := range conflictColumns {
+		onClauses = append(onClauses, fmt.Sprintf("target.%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var updateSet []string
+	for _, c := range updateColumns {
+		if conflictSet[c] {
+			continue
+		}
+		updateSet = append(updateSet, fmt.Sprintf("%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var insertCols, insertVals []string
+	for _, c := range columns {
+		insertCols = append(insertCols, d.Quote(c))
+		insertVals = append(insertVals, fmt.Sprintf("src.%s", d.Quote(c)))
+	}
+
+	sql := fmt.Sprintf(
+		"MERGE INTO %s AS target USING (SELECT %s FROM DUAL) AS src ON (%s) WHEN MATCHED THEN UPDATE SET %s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		d.Quote(tableName),
+		strings.Join(srcSelect, ", "),
+		strings.Join(onClauses, " AND "),
+		strings.Join(updateSet, ", "),
+		strings.Join(insertCols, ", "),
+		strings.Join(insertVals, ", "),
+	)
+
+	return sql, nil
+}
@@ -0,0 +1,57 @@
+package gorm
+
+import "testing"
+
+func TestDmsqlQuote(t *testing.T) {
+	d := dmsql{}
+	if got, want := d.Quote("name"), `"name"`; got != want {
+		t.Errorf("Quote(%q) = %q, want %q", "name", got, want)
+	}
+}
+
+func TestDmsqlLimitAndOffsetSQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		oracleCompat  bool
+		limit, offset interface{}
+		want          string
+	}{
+		{"oracle compat with offset", true, 10, 20, " OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{"oracle compat without offset", true, 10, nil, " OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{"oracle compat offset only", true, nil, 20, " OFFSET 20 ROWS"},
+		{"mysql compat with offset", false, 10, 20, " LIMIT 10 OFFSET 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dmsql{oracleCompatMode: tt.oracleCompat}
+			got, err := d.LimitAndOffsetSQL(tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("LimitAndOffsetSQL returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LimitAndOffsetSQL(%v, %v) = %q, want %q", tt.limit, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDmsqlNormalizeIndexAndColumn(t *testing.T) {
+	d := dmsql{}
+
+	indexName, columnName := d.NormalizeIndexAndColumn("idx_name(10)", "name")
+	if indexName != "idx_name" || columnName != "name(10)" {
+		t.Errorf("NormalizeIndexAndColumn() = (%q, %q), want (%q, %q)", indexName, columnName, "idx_name", "name(10)")
+	}
+
+	indexName, columnName = d.NormalizeIndexAndColumn("idx_name", "name")
+	if indexName != "idx_name" || columnName != "name" {
+		t.Errorf("NormalizeIndexAndColumn() with no prefix length = (%q, %q), want (%q, %q)", indexName, columnName, "idx_name", "name")
+	}
+}
+
+func TestDmsqlSelectFromDummyTable(t *testing.T) {
+	if got, want := (dmsql{}).SelectFromDummyTable(), "FROM DUAL"; got != want {
+		t.Errorf("SelectFromDummyTable() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildUpsertSQL implements Upserter for mssql using a MERGE statement,
+// mssql's equivalent of ON CONFLICT / ON DUPLICATE KEY UPDATE. The returned
+// SQL has one "?" placeholder per entry in columns, in order; the caller
+// binds each row's column values positionally when executing it.
+func (d mssql) BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{}) {
+	conflictSet := make(map[string]bool, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflictSet[c] = true
+	}
+
+	var srcSelect, onClauses []string
+	for _, c := range columns {
+		srcSelect = append(srcSelect, fmt.Sprintf("? AS %s", d.Quote(c)))
+	}
+	for _, c := range conflictColumns {
+		onClauses = append(onClauses, fmt.Sprintf("target.%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var updateSet []string
+	for _, c := range updateColumns {
+		if conflictSet[c] {
+			continue
+		}
+		updateSet = append(updateSet, fmt.Sprintf("%s = src.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	var insertCols, insertVals []string
+	for _, c := range columns {
+		insertCols = append(insertCols, d.Quote(c))
+		insertVals = append(insertVals, fmt.Sprintf("src.%s", d.Quote(c)))
+	}
+
+	sql := fmt.Sprintf(
+		"MERGE INTO %s AS target USING (SELECT %s) AS src ON (%s) WHEN MATCHED THEN UPDATE SET %s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);",
+		d.Quote(tableName),
+		strings.Join(srcSelect, ", "),
+		strings.Join(onClauses, " AND "),
+		strings.Join(updateSet, ", "),
+		strings.Join(insertCols, ", "),
+		strings.Join(insertVals, ", "),
+	)
+
+	return sql, nil
+}
@@ -0,0 +1,39 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildUpsertSQL implements Upserter for mysql using
+// "INSERT ... ON DUPLICATE KEY UPDATE ...". The returned SQL has one "?"
+// placeholder per entry in columns, in order; the caller binds each row's
+// column values positionally when executing it.
+func (d mysql) BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{}) {
+	conflictSet := make(map[string]bool, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflictSet[c] = true
+	}
+
+	var quotedColumns, placeholders, updateSet []string
+	for _, c := range columns {
+		quotedColumns = append(quotedColumns, d.Quote(c))
+		placeholders = append(placeholders, "?")
+	}
+	for _, c := range updateColumns {
+		if conflictSet[c] {
+			continue
+		}
+		updateSet = append(updateSet, fmt.Sprintf("%s = VALUES(%s)", d.Quote(c), d.Quote(c)))
+	}
+
+	sql := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+		d.Quote(tableName),
+		strings.Join(quotedColumns, ", "),
+		strings.Join(placeholders, ", "),
+		strings.Join(updateSet, ", "),
+	)
+
+	return sql, nil
+}
@@ -0,0 +1,44 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildUpsertSQL implements Upserter for postgres using
+// "INSERT ... ON CONFLICT (...) DO UPDATE SET ...". The returned SQL has
+// one "?"-style placeholder per entry in columns, in order; the create
+// callback rewrites each to $n through BindVar as it binds the row's column
+// values positionally.
+func (d postgres) BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{}) {
+	conflictSet := make(map[string]bool, len(conflictColumns))
+	for _, c := range conflictColumns {
+		conflictSet[c] = true
+	}
+
+	var quotedColumns, placeholders, quotedConflictColumns, updateSet []string
+	for _, c := range columns {
+		quotedColumns = append(quotedColumns, d.Quote(c))
+		placeholders = append(placeholders, "?")
+	}
+	for _, c := range conflictColumns {
+		quotedConflictColumns = append(quotedConflictColumns, d.Quote(c))
+	}
+	for _, c := range updateColumns {
+		if conflictSet[c] {
+			continue
+		}
+		updateSet = append(updateSet, fmt.Sprintf("%s = EXCLUDED.%s", d.Quote(c), d.Quote(c)))
+	}
+
+	sql := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		d.Quote(tableName),
+		strings.Join(quotedColumns, ", "),
+		strings.Join(placeholders, ", "),
+		strings.Join(quotedConflictColumns, ", "),
+		strings.Join(updateSet, ", "),
+	)
+
+	return sql, nil
+}
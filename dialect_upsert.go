@@ -0,0 +1,13 @@
+package gorm
+
+// Upserter is implemented by dialects that can translate a conflict-aware
+// insert into a single, dialect-native upsert statement. Creating through
+// DB.Upsert on a dialect that doesn't implement it returns an error.
+type Upserter interface {
+	// BuildUpsertSQL returns the full SQL statement for inserting one row of
+	// values into tableName, updating updateColumns in place when
+	// conflictColumns already identify an existing row. The statement has
+	// one "?" placeholder per entry in columns, in order, which the caller
+	// binds; the implementations in this package return nil args.
+	BuildUpsertSQL(tableName string, columns []string, conflictColumns []string, updateColumns []string) (string, []interface{})
+}
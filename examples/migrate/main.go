@@ -0,0 +1,81 @@
+// Command migrate is a minimal example of driving gorm/migrate from a CLI.
+//
+//	go run ./examples/migrate status
+//	go run ./examples/migrate up
+//	go run ./examples/migrate down
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/jinzhu/gorm/migrate"
+)
+
+type Person struct {
+	ID   uint
+	Name string
+}
+
+func migrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		{
+			ID:          "202601010001",
+			Description: "create people table",
+			Migrate: func(db *gorm.DB) error {
+				return db.AutoMigrate(&Person{}).Error
+			},
+			Rollback: func(db *gorm.DB) error {
+				return db.DropTable(&Person{}).Error
+			},
+		},
+		{
+			ID:          "202601020001",
+			Description: "add email column to people",
+			Migrate: func(db *gorm.DB) error {
+				return db.Exec("ALTER TABLE people ADD COLUMN email VARCHAR(255)").Error
+			},
+			Rollback: func(db *gorm.DB) error {
+				return db.Exec("ALTER TABLE people DROP COLUMN email").Error
+			},
+		},
+	}
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalf("usage: %s [status|up|down]", os.Args[0])
+	}
+
+	db, err := gorm.Open("sqlite3", "migrate_example.db")
+	if err != nil {
+		log.Fatalf("failed to connect database: %v", err)
+	}
+	defer db.Close()
+
+	m := migrate.New(db, migrations())
+
+	switch os.Args[1] {
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			fmt.Printf("%s\tapplied=%v\t%s\n", s.ID, s.Applied, s.Description)
+		}
+	case "up":
+		if err := m.Migrate(); err != nil {
+			log.Fatal(err)
+		}
+	case "down":
+		if err := m.RollbackLast(); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown command %q", os.Args[1])
+	}
+}
@@ -0,0 +1,291 @@
+// Package migrate provides a versioned, reversible schema-migration system
+// on top of gorm.DB, in the spirit of xormigrate/gormigrate.
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Migration describes a single, named schema change.
+type Migration struct {
+	ID          string
+	Description string
+	Migrate     func(*gorm.DB) error
+	Rollback    func(*gorm.DB) error
+}
+
+// migrationRecord is the row stored in the gorm_migrations tracking table.
+type migrationRecord struct {
+	ID          string `gorm:"primary_key;size:255"`
+	AppliedAt   time.Time
+	Description string `gorm:"type:text"`
+}
+
+func (migrationRecord) TableName() string {
+	return "gorm_migrations"
+}
+
+// Migrator applies and rolls back a fixed, ordered list of Migrations,
+// tracking which ones have already run in the gorm_migrations table.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+	initSchema func(*gorm.DB) error
+}
+
+// New returns a Migrator for the given migrations, sorted lexicographically
+// by ID. It panics if two migrations share an ID.
+func New(db *gorm.DB, migrations []*Migration) *Migrator {
+	sorted := make([]*Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	seen := make(map[string]bool, len(sorted))
+	for _, m := range sorted {
+		if seen[m.ID] {
+			panic(fmt.Sprintf("migrate: duplicate migration ID %q", m.ID))
+		}
+		seen[m.ID] = true
+	}
+
+	return &Migrator{db: db, migrations: sorted}
+}
+
+// InitSchema registers a function that creates the consolidated, up-to-date
+// schema in one shot. It only runs when the tracking table is empty, in
+// which case all known migration IDs are recorded as applied without
+// actually running their Migrate funcs.
+func (m *Migrator) InitSchema(fn func(*gorm.DB) error) {
+	m.initSchema = fn
+}
+
+func (m *Migrator) ensureTrackingTable() error {
+	return m.db.AutoMigrate(&migrationRecord{}).Error
+}
+
+func (m *Migrator) appliedIDs() (map[string]bool, error) {
+	var records []migrationRecord
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(records))
+	for _, r := range records {
+		applied[r.ID] = true
+	}
+	return applied, nil
+}
+
+// checkConsistency fails fast if a migration ID that has been applied in
+// the database is no longer present in the code, which would otherwise be
+// silently skipped.
+func (m *Migrator) checkConsistency(applied map[string]bool) error {
+	known := make(map[string]bool, len(m.migrations))
+	for _, mg := range m.migrations {
+		known[mg.ID] = true
+	}
+	for id := range applied {
+		if !known[id] {
+			return fmt.Errorf("migrate: migration %q is recorded as applied but is missing from the code", id)
+		}
+	}
+	return nil
+}
+
+// supportsTransactionalDDL reports whether the dialect can run DDL inside a
+// transaction safely. MySQL-family dialects implicitly commit on DDL, so
+// migrations there run statement-by-statement with best-effort rollback.
+func supportsTransactionalDDL(db *gorm.DB) bool {
+	switch db.Dialect().GetName() {
+	case "mysql", "dmsql", "db2":
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *Migrator) runMigration(mg *Migration) error {
+	if !supportsTransactionalDDL(m.db) {
+		if err := mg.Migrate(m.db); err != nil {
+			if mg.Rollback != nil {
+				mg.Rollback(m.db) // best-effort; the dialect can't roll back DDL anyway
+			}
+			return err
+		}
+		return m.db.Create(&migrationRecord{ID: mg.ID, AppliedAt: time.Now(), Description: mg.Description}).Error
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := mg.Migrate(tx); err != nil {
+			return err
+		}
+		return tx.Create(&migrationRecord{ID: mg.ID, AppliedAt: time.Now(), Description: mg.Description}).Error
+	})
+}
+
+// Migrate runs every migration that has not yet been applied, in ID order.
+func (m *Migrator) Migrate() error {
+	return m.MigrateTo("")
+}
+
+// MigrateTo runs every unapplied migration up to and including id. An empty
+// id means run everything. When the tracking table is empty and InitSchema
+// was set, it runs that function instead and records every known migration
+// as applied.
+func (m *Migrator) MigrateTo(id string) error {
+	if err := m.ensureTrackingTable(); err != nil {
+		return err
+	}
+
+	applied, err := m.appliedIDs()
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 && m.initSchema != nil {
+		if err := m.initSchema(m.db); err != nil {
+			return err
+		}
+		for _, mg := range m.migrations {
+			// InitSchema builds the full schema, so every known migration is
+			// recorded as applied, whatever id is.
+			if err := m.db.Create(&migrationRecord{ID: mg.ID, AppliedAt: time.Now(), Description: mg.Description}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := m.checkConsistency(applied); err != nil {
+		return err
+	}
+
+	for _, mg := range m.migrations {
+		if applied[mg.ID] {
+			// Still respect id as the stopping point even when already
+			// applied, so MigrateTo(id) on an up-to-date-through-id database
+			// is a no-op instead of running everything after it.
+			if id != "" && mg.ID == id {
+				break
+			}
+			continue
+		}
+		if err := m.runMigration(mg); err != nil {
+			return fmt.Errorf("migrate: migration %q failed: %w", mg.ID, err)
+		}
+		if id != "" && mg.ID == id {
+			break
+		}
+	}
+	return nil
+}
+
+// RollbackLast rolls back the applied migration that sorts last by ID.
+func (m *Migrator) RollbackLast() error {
+	applied, err := m.appliedIDs()
+	if err != nil {
+		return err
+	}
+	if err := m.checkConsistency(applied); err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mg := m.migrations[i]
+		if !applied[mg.ID] {
+			continue
+		}
+		return m.rollbackOne(mg)
+	}
+	return nil
+}
+
+// RollbackTo rolls back every applied migration after id, in reverse order.
+// An empty id rolls back everything.
+func (m *Migrator) RollbackTo(id string) error {
+	applied, err := m.appliedIDs()
+	if err != nil {
+		return err
+	}
+	if err := m.checkConsistency(applied); err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mg := m.migrations[i]
+		if mg.ID == id {
+			break
+		}
+		if !applied[mg.ID] {
+			continue
+		}
+		if err := m.rollbackOne(mg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) rollbackOne(mg *Migration) error {
+	if mg.Rollback == nil {
+		return fmt.Errorf("migrate: migration %q has no Rollback func", mg.ID)
+	}
+
+	run := func(db *gorm.DB) error {
+		if err := mg.Rollback(db); err != nil {
+			return err
+		}
+		return db.Where("id = ?", mg.ID).Delete(&migrationRecord{}).Error
+	}
+
+	if !supportsTransactionalDDL(m.db) {
+		if err := run(m.db); err != nil {
+			return fmt.Errorf("migrate: rollback of %q failed: %w", mg.ID, err)
+		}
+		return nil
+	}
+
+	if err := m.db.Transaction(run); err != nil {
+		return fmt.Errorf("migrate: rollback of %q failed: %w", mg.ID, err)
+	}
+	return nil
+}
+
+// MigrationStatus reports whether a single migration has been applied.
+type MigrationStatus struct {
+	ID          string
+	Description string
+	Applied     bool
+	AppliedAt   time.Time
+}
+
+// Status returns the applied/pending state of every known migration, in ID order.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.ensureTrackingTable(); err != nil {
+		return nil, err
+	}
+
+	var records []migrationRecord
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	recordsByID := make(map[string]migrationRecord, len(records))
+	for _, r := range records {
+		recordsByID[r.ID] = r
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, mg := range m.migrations {
+		r, ok := recordsByID[mg.ID]
+		statuses[i] = MigrationStatus{
+			ID:          mg.ID,
+			Description: mg.Description,
+			Applied:     ok,
+			AppliedAt:   r.AppliedAt,
+		}
+	}
+	return statuses, nil
+}
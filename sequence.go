@@ -0,0 +1,74 @@
+package gorm
+
+// EnsureSequences and DropSequences are a manual, opt-in counterpart to
+// CreateTable/DropTable/AutoMigrate for sequence-backed primary keys.
+//
+// CreateTable, DropTable and AutoMigrate are plain *DB methods defined in
+// this package's main.go, not callback-driven, so there is no extension
+// point this package can hook to run them automatically alongside those
+// methods without editing main.go directly. Call these explicitly until
+// that wiring lands, e.g.:
+//
+//	db.AutoMigrate(&Order{})
+//	gorm.EnsureSequences(db, &Order{})
+
+// EnsureSequences creates the sequences backing values' sequence-tagged (or
+// UseSequences-mode) primary keys, for dialects implementing sequenceDialect.
+// It is a no-op for any value whose dialect or primary key isn't
+// sequence-backed.
+func EnsureSequences(db *DB, values ...interface{}) error {
+	dialect, ok := db.Dialect().(sequenceDialect)
+	if !ok {
+		return nil
+	}
+
+	for _, value := range values {
+		scope := db.NewScope(value)
+		field := scope.PrimaryField()
+		if field == nil {
+			continue
+		}
+
+		seqName, ok := dialect.SequencedPrimaryKeyName(scope.TableName(), field.StructField)
+		if !ok {
+			continue
+		}
+
+		if !dialect.HasSequence(seqName) {
+			if err := dialect.CreateSequence(seqName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DropSequences drops the sequences EnsureSequences would have created for
+// values, mirroring DropTable for sequence-backed primary keys.
+func DropSequences(db *DB, values ...interface{}) error {
+	dialect, ok := db.Dialect().(sequenceDialect)
+	if !ok {
+		return nil
+	}
+
+	for _, value := range values {
+		scope := db.NewScope(value)
+		field := scope.PrimaryField()
+		if field == nil {
+			continue
+		}
+
+		seqName, ok := dialect.SequencedPrimaryKeyName(scope.TableName(), field.StructField)
+		if !ok {
+			continue
+		}
+
+		if !dialect.HasSequence(seqName) {
+			continue
+		}
+		if err := dialect.DropSequence(seqName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
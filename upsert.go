@@ -0,0 +1,26 @@
+package gorm
+
+// upsertClause carries the conflict/update column configuration set by
+// DB.Upsert through to the create callback chain.
+type upsertClause struct {
+	conflictColumns []string
+	updateColumns   []string
+}
+
+// Upsert returns a new *DB session configured to perform an upsert on the
+// given conflict columns, updating updateColumns when a row already exists
+// for them. Chain it with Create so application code doesn't have to branch
+// on dialect name to get ON CONFLICT / MERGE / ON DUPLICATE KEY behaviour:
+//
+//	db.Upsert([]string{"id"}, []string{"name", "updated_at"}).Create(&obj)
+//
+// Only dialects that implement Upserter can honour this (postgres, mysql,
+// mssql, dmsql and db2 all do); Create returns an error for any other
+// dialect rather than silently falling back to a plain INSERT, which would
+// violate the conflict semantics the caller asked for.
+func (s *DB) Upsert(conflictColumns []string, updateColumns []string) *DB {
+	return s.Set("gorm:upsert_clause", &upsertClause{
+		conflictColumns: conflictColumns,
+		updateColumns:   updateColumns,
+	})
+}